@@ -0,0 +1,106 @@
+package errs
+
+import (
+	"context"
+	"sync"
+)
+
+// ExecConcurrent runs all Add/AddCtx-ed functions in parallel
+// goroutines, bounded to at most maxParallel running at once. If
+// maxParallel is 0 or negative, all functions are started at once.
+//
+// AddCtx functions are passed a context derived from ctx that is
+// canceled as soon as any task errors (or ctx itself is canceled),
+// so long-running tasks can check it to stop early; tasks that have
+// not started yet when that happens are skipped. Plain Add functions
+// do not receive the context and always run to completion once
+// started.
+//
+// Once every task has returned (or been skipped), Defer and DeferE
+// functions run LIFO, same as Exec, followed by Final functions.
+// Errors from tasks and from DeferE are combined the same way as
+// Exec: nil if none, the error itself if there is exactly one, or a
+// combined error implementing Unwrap() []error otherwise.
+//
+// RecoverPanics applies here too: a panic in a task, a Defer/DeferE
+// or a Final is recovered and fed into the same aggregation path,
+// and each Final still runs under its own recover.
+func (g Group) ExecConcurrent(ctx context.Context, maxParallel int) (err error) {
+	defer func() {
+		var finalErrs []error
+		for _, f := range g.final {
+			if ferr := g.runFinal(f); ferr != nil {
+				finalErrs = append(finalErrs, ferr)
+			}
+		}
+		if len(finalErrs) > 0 {
+			if err != nil {
+				finalErrs = append([]error{err}, finalErrs...)
+			}
+			err = combine(finalErrs)
+		}
+	}()
+
+	type indexed struct {
+		i  int
+		fn fn
+	}
+	var tasks []indexed
+	var defers []indexed
+	for i, fn := range g.funcs {
+		if fn.d {
+			defers = append([]indexed{{i, fn}}, defers...)
+			continue
+		}
+		tasks = append(tasks, indexed{i, fn})
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if maxParallel <= 0 || maxParallel > len(tasks) {
+		maxParallel = len(tasks)
+	}
+
+	var (
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+	)
+	sem := make(chan struct{}, maxParallel)
+	for _, task := range tasks {
+		if cctx.Err() != nil {
+			break
+		}
+		task := task
+		sem <- struct{}{}
+		// The send above can block while a sibling task is running;
+		// once a slot frees up, re-check cctx.Err() before launching,
+		// since it may have been canceled while we were waiting.
+		if cctx.Err() != nil {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if taskErr := g.execStep(cctx, task.fn); taskErr != nil {
+				mu.Lock()
+				errs = append(errs, g.wrap(task.i, task.fn, taskErr))
+				mu.Unlock()
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, d := range defers {
+		if deferErr := g.execStep(context.Background(), d.fn); deferErr != nil {
+			errs = append(errs, g.wrap(d.i, d.fn, deferErr))
+		}
+	}
+
+	err = combine(errs)
+	return
+}
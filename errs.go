@@ -17,29 +17,121 @@
 package errs
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"reflect"
 	"sync"
 )
 
 type fn struct {
-	f func() error
-	d bool // defer
+	f    func() error
+	c    func(context.Context) error
+	name string // set by AddNamed/AddFNamed
+	d    bool   // defer
+}
+
+// call invokes whichever of f or c was set, passing ctx to c.
+func (fn fn) call(ctx context.Context) error {
+	if fn.c != nil {
+		return fn.c(ctx)
+	}
+	return fn.f()
 }
 
 // Group is a group of functions.
 // Empty value of Group is usable.
 type Group struct {
+	// ContinueOnError, when true, makes Exec run every Add-ed
+	// function regardless of earlier failures, instead of stopping
+	// at the first error. If more than one fails, the errors are
+	// combined into a single error that implements Unwrap() []error,
+	// so errors.Is and errors.As still work against any of them; a
+	// single error is returned as-is.
+	ContinueOnError bool
+
+	// RecoverPanics, when true, recovers panics in every Add, AddCtx,
+	// Defer, DeferE and Final function instead of letting them
+	// propagate. A recovered panic is converted to an error (see
+	// panicError) and fed into the same aggregation path as a normal
+	// error returned by that function. Each Final runs under its own
+	// recover, so a panicking Final does not prevent the rest of the
+	// Final functions from running.
+	RecoverPanics bool
+
+	// WrapWith, if set, is called with the registration index of
+	// every function that errors (its position among all Add,
+	// AddCtx, Defer and DeferE calls) and the error it returned
+	// (already wrapped with its name, for AddNamed/AddFNamed steps),
+	// and its result replaces that error. Use it for things like
+	// attaching a stack trace or a structured logging ID.
+	WrapWith func(stepIndex int, err error) error
+
 	funcs []fn
 	final []func()
 }
 
+// execStep calls fn, recovering a panic into a *panicError if
+// g.RecoverPanics is set.
+func (g Group) execStep(ctx context.Context, fn fn) error {
+	if !g.RecoverPanics {
+		return fn.call(ctx)
+	}
+	return recovered(func() error { return fn.call(ctx) })
+}
+
+// runFinal calls f, recovering a panic if g.RecoverPanics is set so
+// that it does not prevent subsequent Final functions from running.
+func (g Group) runFinal(f func()) (err error) {
+	if !g.RecoverPanics {
+		f()
+		return nil
+	}
+	return recovered(func() error { f(); return nil })
+}
+
+// wrap applies fn's name (if any) and the Group's WrapWith hook (if
+// set) to err, in that order.
+func (g Group) wrap(i int, fn fn, err error) error {
+	if fn.name != "" {
+		err = fmt.Errorf("%s: %w", fn.name, err)
+	}
+	if g.WrapWith != nil {
+		err = g.WrapWith(i, err)
+	}
+	return err
+}
+
 // Add adds f to the group of functions.
 // Functions are executed FIFO.
 func (g *Group) Add(f func() error) {
 	g.funcs = append(g.funcs, fn{f: f})
 }
 
+// AddNamed is like Add but wraps f's error with name using %w, e.g.
+// "name: original error", so the error returned by Exec records which
+// of a group of steps failed. errors.Is and errors.As still match the
+// original error through the wrapping.
+func (g *Group) AddNamed(name string, f func() error) {
+	g.funcs = append(g.funcs, fn{f: f, name: name})
+}
+
+// AddFNamed is like AddF but wraps f's error with name, same as
+// AddNamed.
+func (g *Group) AddFNamed(name string, f interface{}, args ...interface{}) Filler {
+	filler := g.AddF(f, args...)
+	g.funcs[len(g.funcs)-1].name = name
+	return filler
+}
+
+// AddCtx is like Add but f receives a context.Context. Under Exec, f
+// is called with context.Background(); under ExecConcurrent, it is
+// called with a context that is canceled as soon as any task in the
+// group errors, so a long-running f can use ctx to stop early.
+func (g *Group) AddCtx(f func(context.Context) error) {
+	g.funcs = append(g.funcs, fn{c: f})
+}
+
 // AddF is like Add but takes in a function
 // and its arguments for cleaner code.
 //
@@ -93,6 +185,29 @@ func (g *Group) Defer(f func()) {
 	g.funcs = append(g.funcs, fn{f: func() error { f(); return nil }, d: true})
 }
 
+// DeferE is like Defer but f's returned error is not dropped: it is
+// collected and, if non-nil, included in the error returned by Exec.
+// DeferE functions are executed LIFO, alongside Defer functions.
+func (g *Group) DeferE(f func() error) {
+	g.funcs = append(g.funcs, fn{f: f, d: true})
+}
+
+// Close registers c.Close as a DeferE, so that a close error is not
+// silently dropped, e.g.
+//  f, err := os.Create(path)
+//  if err != nil {
+//      return err
+//  }
+//  e.AddF(io.Copy, f, src)
+//  e.Close(f)
+// c.Close is bound when Close is called, so c must already be the
+// resource to close; a variable that a Filler fills in only during
+// Exec, such as f in e.AddF(os.Open, path).Fill(&f), is still nil
+// at that point and the resource it later holds is not closed.
+func (g *Group) Close(c io.Closer) {
+	g.DeferE(c.Close)
+}
+
 // Final adds a function that is guaranteed to be executed
 // even if an error is returned.
 // Final functions are executed FIFO.
@@ -105,30 +220,58 @@ func (g *Group) Final(f func()) {
 // If no error is encountered, returns nil.
 // If an error is returned, defer functions preceding the error
 // returning function are executed.
-func (g Group) Exec() error {
+//
+// If ContinueOnError is true, Exec instead runs every Add-ed
+// function regardless of earlier failures.
+//
+// Regardless of ContinueOnError, errors from DeferE (and Close)
+// functions are always collected. If there is more than one error
+// in total, they are combined into a single error that implements
+// Unwrap() []error (see combine); with exactly one, that error is
+// returned as-is.
+func (g Group) Exec() (err error) {
 	defer func() {
+		var finalErrs []error
 		for _, f := range g.final {
-			f()
+			if ferr := g.runFinal(f); ferr != nil {
+				finalErrs = append(finalErrs, ferr)
+			}
+		}
+		if len(finalErrs) > 0 {
+			if err != nil {
+				finalErrs = append([]error{err}, finalErrs...)
+			}
+			err = combine(finalErrs)
 		}
 	}()
 
-	var defers []func() error
-	var err error
-	for _, fn := range g.funcs {
+	type deferred struct {
+		i  int
+		fn fn
+	}
+	var defers []deferred
+	var errs []error
+	for i, fn := range g.funcs {
 		if fn.d {
-			defers = append([]func() error{fn.f}, defers...)
+			defers = append([]deferred{{i, fn}}, defers...)
 			continue
 		}
-		if err = fn.f(); err != nil {
-			break
+		if stepErr := g.execStep(context.Background(), fn); stepErr != nil {
+			errs = append(errs, g.wrap(i, fn, stepErr))
+			if !g.ContinueOnError {
+				break
+			}
 		}
 	}
 
-	for _, fn := range defers {
-		fn()
+	for _, d := range defers {
+		if stepErr := g.execStep(context.Background(), d.fn); stepErr != nil {
+			errs = append(errs, g.wrap(d.i, d.fn, stepErr))
+		}
 	}
 
-	return err
+	err = combine(errs)
+	return
 }
 
 // Filler fills in the return values of a
@@ -151,6 +294,12 @@ func (f sliceFiller) FillAt(i int, arg interface{}) {
 	reflect.ValueOf(arg).Elem().Set(reflect.ValueOf(f[i]))
 }
 
+// asyncFiller is safe to use from ExecConcurrent: set and FillAt both
+// take the same mutex, so a task running in a goroutine can call
+// set concurrently with another goroutine's Fill/FillAt call made
+// before the values are ready. Once ready is true, set is never
+// called again for this filler, so reads after that point are safe
+// without further synchronization.
 type asyncFiller struct {
 	values []interface{}
 	toFill []struct {
@@ -1,9 +1,12 @@
 package errs
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -82,6 +85,246 @@ func TestFinal(t *testing.T) {
 	}
 }
 
+func TestContinueOnError(t *testing.T) {
+	e := Group{ContinueOnError: true}
+	err1 := errors.New("error 1")
+	err2 := errors.New("error 2")
+	e.Add(func() error { return err1 })
+	e.Add(okFunc)
+	e.Add(func() error { return err2 })
+	err := e.Exec()
+	if err == nil {
+		t.Fatal("Expected error, found nil")
+	}
+	var m interface{ Errors() []error }
+	if !errors.As(err, &m) {
+		t.Fatalf("Expected combined error, found %v", err)
+	}
+	assert(t, m.Errors(), []error{err1, err2})
+	if !errors.Is(err, err1) || !errors.Is(err, err2) {
+		t.Error("Expected errors.Is to match both underlying errors")
+	}
+}
+
+func TestContinueOnErrorSingle(t *testing.T) {
+	e := Group{ContinueOnError: true}
+	e.Add(okFunc)
+	e.Add(errFunc)
+	e.Add(okFunc)
+	err := e.Exec()
+	if err == nil {
+		t.Fatal("Expected error, found nil")
+	}
+	if _, ok := err.(interface{ Errors() []error }); ok {
+		t.Error("Expected a single error to not be wrapped in a combined error")
+	}
+}
+
+func TestMultiErrorFormat(t *testing.T) {
+	e := Group{ContinueOnError: true}
+	err1 := errors.New("error 1")
+	err2 := errors.New("error 2")
+	e.Add(func() error { return err1 })
+	e.Add(func() error { return err2 })
+	err := e.Exec()
+
+	wantLine := "1: error 1\n2: error 2"
+	if err.Error() != wantLine {
+		t.Errorf("Error() = %q, want %q", err.Error(), wantLine)
+	}
+	if got := fmt.Sprintf("%+v", err); got != wantLine {
+		t.Errorf("%%+v = %q, want %q", got, wantLine)
+	}
+}
+
+func TestDeferE(t *testing.T) {
+	var e Group
+	closeErr := errors.New("close error")
+	e.Add(okFunc)
+	e.DeferE(func() error { return closeErr })
+	err := e.Exec()
+	if err != closeErr {
+		t.Errorf("Expected %v, found %v", closeErr, err)
+	}
+}
+
+func TestDeferECombinedWithPrimary(t *testing.T) {
+	var e Group
+	primaryErr := errors.New("primary error")
+	closeErr := errors.New("close error")
+	e.DeferE(func() error { return closeErr })
+	e.Add(func() error { return primaryErr })
+	err := e.Exec()
+	if !errors.Is(err, primaryErr) || !errors.Is(err, closeErr) {
+		t.Errorf("Expected combined error wrapping both, found %v", err)
+	}
+}
+
+type closerFunc func() error
+
+func (c closerFunc) Close() error { return c() }
+
+func TestClose(t *testing.T) {
+	var e Group
+	closeErr := errors.New("close error")
+	e.Add(okFunc)
+	e.Close(closerFunc(func() error { return closeErr }))
+	if err := e.Exec(); err != closeErr {
+		t.Errorf("Expected %v, found %v", closeErr, err)
+	}
+}
+
+func TestExecConcurrent(t *testing.T) {
+	var e Group
+	var mu sync.Mutex
+	var sum int
+	for i := 1; i <= 5; i++ {
+		i := i
+		e.Add(func() error {
+			mu.Lock()
+			sum += i
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := e.ExecConcurrent(context.Background(), 2); err != nil {
+		t.Errorf("Expected nil, found error: %v", err)
+	}
+	if sum != 15 {
+		t.Errorf("Expected 15, found %v", sum)
+	}
+}
+
+func TestExecConcurrentErrors(t *testing.T) {
+	var e Group
+	err1 := errors.New("error 1")
+	err2 := errors.New("error 2")
+	e.Add(func() error { return err1 })
+	e.Add(func() error { return err2 })
+	e.Add(okFunc)
+	err := e.ExecConcurrent(context.Background(), 0)
+	if !errors.Is(err, err1) || !errors.Is(err, err2) {
+		t.Errorf("Expected combined error wrapping both, found %v", err)
+	}
+}
+
+func TestExecConcurrentSkipsQueuedTaskAfterCancel(t *testing.T) {
+	// maxParallel is strictly less than the number of tasks, so the
+	// second task must queue on the semaphore behind the first. The
+	// first task errors and cancels the context before releasing its
+	// slot, so the second task, unblocked by that same release,
+	// should never run.
+	var e Group
+	errBoom := errors.New("boom")
+	var started int32
+	e.Add(func() error { return errBoom })
+	e.Add(func() error {
+		atomic.AddInt32(&started, 1)
+		return nil
+	})
+	err := e.ExecConcurrent(context.Background(), 1)
+	if !errors.Is(err, errBoom) {
+		t.Errorf("Expected error wrapping %v, found %v", errBoom, err)
+	}
+	if n := atomic.LoadInt32(&started); n != 0 {
+		t.Errorf("Expected queued task to be skipped after cancellation, but it ran")
+	}
+}
+
+func TestAddCtxCanceledOnSiblingError(t *testing.T) {
+	var e Group
+	errBoom := errors.New("boom")
+	started := make(chan struct{})
+	e.AddCtx(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	e.AddCtx(func(ctx context.Context) error {
+		<-started
+		return errBoom
+	})
+	err := e.ExecConcurrent(context.Background(), 2)
+	if !errors.Is(err, errBoom) {
+		t.Errorf("Expected error wrapping %v, found %v", errBoom, err)
+	}
+}
+
+func TestAddNamed(t *testing.T) {
+	var e Group
+	wrapped := errors.New("underlying")
+	e.AddNamed("open config", func() error { return wrapped })
+	err := e.Exec()
+	if err == nil {
+		t.Fatal("Expected error, found nil")
+	}
+	if !errors.Is(err, wrapped) {
+		t.Errorf("Expected errors.Is to match the underlying error, found %v", err)
+	}
+	if err.Error() != "open config: underlying" {
+		t.Errorf("Expected %q, found %q", "open config: underlying", err.Error())
+	}
+}
+
+func TestAddFNamed(t *testing.T) {
+	var e Group
+	var n int64
+	e.AddFNamed("copy", func() (int64, error) { return 0, errFunc() }).Fill(&n)
+	err := e.Exec()
+	if err == nil || err.Error() != "copy: error" {
+		t.Errorf("Expected %q, found %v", "copy: error", err)
+	}
+}
+
+func TestWrapWith(t *testing.T) {
+	var e Group
+	e.WrapWith = func(stepIndex int, err error) error {
+		return fmt.Errorf("step %d: %w", stepIndex, err)
+	}
+	e.Add(okFunc)
+	e.Add(errFunc)
+	err := e.Exec()
+	if err == nil || err.Error() != "step 1: error" {
+		t.Errorf("Expected %q, found %v", "step 1: error", err)
+	}
+}
+
+func TestRecoverPanics(t *testing.T) {
+	e := Group{RecoverPanics: true}
+	e.Add(func() error { panic("boom") })
+	err := e.Exec()
+	if err == nil {
+		t.Fatal("Expected error, found nil")
+	}
+	if err.Error() != "panic: boom" {
+		t.Errorf(`Expected "panic: boom", found %q`, err.Error())
+	}
+}
+
+func TestRecoverPanicsPropagatesWithoutOptIn(t *testing.T) {
+	var e Group
+	e.Add(func() error { panic("boom") })
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic to propagate when RecoverPanics is false")
+		}
+	}()
+	e.Exec()
+}
+
+func TestRecoverPanicsFinalsAllRun(t *testing.T) {
+	e := Group{RecoverPanics: true}
+	var ran []int
+	e.Add(func() error { panic("boom") })
+	e.Final(func() { panic("final 1") })
+	e.Final(func() { ran = append(ran, 2) })
+	err := e.Exec()
+	if err == nil {
+		t.Fatal("Expected error, found nil")
+	}
+	assert(t, ran, []int{2})
+}
+
 func assert(t *testing.T, a, b interface{}) {
 	if !reflect.DeepEqual(a, b) {
 		t.Errorf("%v != %v", a, b)
@@ -0,0 +1,64 @@
+package errs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// multiError combines multiple errors into one. It implements
+// Unwrap() []error so it works with errors.Is and errors.As per
+// Go 1.20's multi-error semantics.
+type multiError struct {
+	errs []error
+}
+
+// combine merges errs into a single error.
+// It returns nil if errs is empty, the error itself if there is
+// exactly one, or a *multiError wrapping all of them otherwise.
+func combine(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return &multiError{errs: errs}
+	}
+}
+
+// Errors returns the individual errors that make up the combined error.
+func (m *multiError) Errors() []error {
+	return m.errs
+}
+
+// Unwrap returns the individual errors, for use with errors.Is and
+// errors.As.
+func (m *multiError) Unwrap() []error {
+	return m.errs
+}
+
+func (m *multiError) Error() string {
+	var b strings.Builder
+	for i, err := range m.errs {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "%d: %v", i+1, err)
+	}
+	return b.String()
+}
+
+// Format implements fmt.Formatter so %+v prints each error on its own
+// line, indexed, the same way as the default string form.
+func (m *multiError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		for i, err := range m.errs {
+			if i > 0 {
+				fmt.Fprint(s, "\n")
+			}
+			fmt.Fprintf(s, "%d: %+v", i+1, err)
+		}
+		return
+	}
+	fmt.Fprint(s, m.Error())
+}
@@ -0,0 +1,59 @@
+package errs
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// panicError is the error produced when Group.RecoverPanics is true
+// and a registered function panics. It carries the recovered value
+// and the stack at the point of the panic, captured via
+// runtime.Callers.
+type panicError struct {
+	value interface{}
+	stack []uintptr
+}
+
+func newPanicError(value interface{}) *panicError {
+	var pcs [32]uintptr
+	n := runtime.Callers(3, pcs[:])
+	return &panicError{value: value, stack: pcs[:n]}
+}
+
+// Value returns the value passed to panic.
+func (p *panicError) Value() interface{} {
+	return p.value
+}
+
+func (p *panicError) Error() string {
+	return fmt.Sprintf("panic: %v", p.value)
+}
+
+// Format implements fmt.Formatter; %+v additionally prints the stack
+// captured at the point of the panic.
+func (p *panicError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		fmt.Fprintf(s, "panic: %v\n", p.value)
+		frames := runtime.CallersFrames(p.stack)
+		for {
+			frame, more := frames.Next()
+			fmt.Fprintf(s, "\t%s\n\t\t%s:%d\n", frame.Function, frame.File, frame.Line)
+			if !more {
+				break
+			}
+		}
+		return
+	}
+	fmt.Fprint(s, p.Error())
+}
+
+// recovered runs f, converting a panic into a *panicError instead of
+// letting it propagate.
+func recovered(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = newPanicError(r)
+		}
+	}()
+	return f()
+}